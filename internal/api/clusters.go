@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/tracing"
+)
+
+// ClusterInfo is the wire representation of one cluster known to the
+// Runner, as returned by the /clusters endpoint.
+type ClusterInfo struct {
+	ID        string `json:"id"`
+	Context   string `json:"context"`
+	Connected bool   `json:"connected"`
+	Healthy   bool   `json:"healthy"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ClusterHealthChecker reports whether a cluster's API server is
+// currently reachable, e.g. via a lightweight discovery call.
+type ClusterHealthChecker func(ctx context.Context, id cluster.ID) error
+
+// StackResolver resolves a cluster ID to that cluster's live dashboard
+// config, building its per-cluster stack on first use. It is the bridge
+// between a session's active cluster ID and the object store, module
+// manager, and describers that stack actually provides.
+type StackResolver func(ctx context.Context, id cluster.ID) (config.Dash, error)
+
+// ClusterSwitcher tracks which cluster is "active" for a given
+// websocket session, so module lookups and describers for that session
+// route to the right per-cluster stack.
+type ClusterSwitcher struct {
+	set          *cluster.ClusterSet
+	healthCheck  ClusterHealthChecker
+	resolver     StackResolver
+	mu           sync.Mutex
+	activeByConn map[string]cluster.ID
+	defaultID    cluster.ID
+	tracer       trace.Tracer
+}
+
+// NewClusterSwitcher creates a ClusterSwitcher over set, defaulting every
+// new session to defaultID until it explicitly switches. tracer is used
+// to create a span around each cluster's connectivity/health check in
+// List; it may be nil in tests. resolver connects an active cluster ID
+// to its per-cluster stack for ActiveDashConfig; it may be nil if the
+// caller only needs List/SetActive.
+func NewClusterSwitcher(set *cluster.ClusterSet, defaultID cluster.ID, healthCheck ClusterHealthChecker, tracer trace.Tracer, resolver StackResolver) *ClusterSwitcher {
+	return &ClusterSwitcher{
+		set:          set,
+		healthCheck:  healthCheck,
+		resolver:     resolver,
+		activeByConn: make(map[string]cluster.ID),
+		defaultID:    defaultID,
+		tracer:       tracer,
+	}
+}
+
+// Active returns the cluster ID the given websocket session is currently
+// pointed at, defaulting to the Runner's initial cluster.
+func (s *ClusterSwitcher) Active(connID string) cluster.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.activeByConn[connID]; ok {
+		return id
+	}
+	return s.defaultID
+}
+
+// ActiveDashConfig resolves connID's active cluster to its live dashboard
+// config via the configured StackResolver, so module lookups and
+// describers for that session route to the correct per-cluster stack.
+func (s *ClusterSwitcher) ActiveDashConfig(ctx context.Context, connID string) (config.Dash, error) {
+	if s.resolver == nil {
+		return nil, fmt.Errorf("cluster switcher has no stack resolver configured")
+	}
+	return s.resolver(ctx, s.Active(connID))
+}
+
+// SetActive switches connID's active cluster, validating that id is
+// registered in the underlying ClusterSet.
+func (s *ClusterSwitcher) SetActive(connID string, id cluster.ID) error {
+	if _, ok := s.set.Spec(id); !ok {
+		return fmt.Errorf("unknown cluster %s", id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activeByConn[connID] = id
+	return nil
+}
+
+// Forget drops any active-cluster tracking for a closed websocket
+// session.
+func (s *ClusterSwitcher) Forget(connID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.activeByConn, connID)
+}
+
+// List returns the known clusters with their current connectivity and
+// health state.
+func (s *ClusterSwitcher) List(ctx context.Context) []ClusterInfo {
+	ids := s.set.IDs()
+	infos := make([]ClusterInfo, 0, len(ids))
+
+	for _, id := range ids {
+		spec, _ := s.set.Spec(id)
+		info := ClusterInfo{ID: string(id), Context: spec.Context}
+
+		clusterCtx := ctx
+		var span trace.Span
+		if s.tracer != nil {
+			clusterCtx, span = s.tracer.Start(ctx, "cluster.HealthCheck", trace.WithAttributes(tracing.ClusterAttributes(spec.Context, spec.Namespace)...))
+		}
+
+		if _, err := s.set.Get(clusterCtx, id); err != nil {
+			info.Error = err.Error()
+			infos = append(infos, info)
+			if span != nil {
+				span.End()
+			}
+			continue
+		}
+		info.Connected = true
+
+		if s.healthCheck != nil {
+			if err := s.healthCheck(clusterCtx, id); err != nil {
+				info.Error = err.Error()
+			} else {
+				info.Healthy = true
+			}
+		} else {
+			info.Healthy = true
+		}
+		if span != nil {
+			span.End()
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// ClustersHandler serves GET /clusters, listing every known cluster and
+// its connectivity/health. tracer, if non-nil, roots a span for the
+// request that List's per-cluster health-check spans nest under.
+func ClustersHandler(switcher *ClusterSwitcher, tracer trace.Tracer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if tracer != nil {
+			var span trace.Span
+			ctx, span = tracer.Start(ctx, "HTTP GET /clusters")
+			defer span.End()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(switcher.List(ctx)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/vmware-tanzu/octant/internal/tracing"
+)
+
+// ID stably identifies one cluster within a ClusterSet, derived from its
+// kubeconfig path and context so the same cluster gets the same ID
+// across restarts.
+type ID string
+
+// Spec describes a single cluster a ClusterSet can connect to.
+type Spec struct {
+	KubeConfigPath string
+	Context        string
+	Namespace      string
+	Namespaces     []string
+}
+
+// IDFor returns the stable ID for a kubeconfig path + context pair.
+func IDFor(kubeConfigPath, context string) ID {
+	h := sha256.Sum256([]byte(kubeConfigPath + "#" + context))
+	return ID(hex.EncodeToString(h[:])[:12])
+}
+
+// entry is a lazily-connected cluster: client is nil until first Get. mu
+// guards client and is held across the (possibly slow) dial so concurrent
+// Get calls for the same cluster don't race to connect twice, without
+// blocking Get/Spec/IDs calls for any other cluster.
+type entry struct {
+	spec Spec
+
+	mu     sync.Mutex
+	client ClientInterface
+}
+
+// ClusterSet manages connections to multiple Kubernetes clusters,
+// identified by kubeconfig + context, and only dials a cluster's API
+// server (building its client) the first time it is requested. It does
+// not build informers or any other per-cluster component.
+type ClusterSet struct {
+	mu                sync.Mutex
+	restConfigOptions RESTConfigOptions
+	entries           map[ID]*entry
+	tracer            trace.Tracer
+}
+
+// NewClusterSet creates an empty ClusterSet. Use Add to register the
+// clusters it should know about. tracer is used to create a span around
+// each cluster dial in Get; it may be nil in tests.
+func NewClusterSet(restConfigOptions RESTConfigOptions, tracer trace.Tracer) *ClusterSet {
+	return &ClusterSet{
+		restConfigOptions: restConfigOptions,
+		entries:           make(map[ID]*entry),
+		tracer:            tracer,
+	}
+}
+
+// Add registers a cluster spec, returning its stable ID. It does not
+// connect to the cluster; that happens lazily on first Get.
+func (cs *ClusterSet) Add(spec Spec) ID {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	id := IDFor(spec.KubeConfigPath, spec.Context)
+	if _, ok := cs.entries[id]; !ok {
+		cs.entries[id] = &entry{spec: spec}
+	}
+	return id
+}
+
+// Remove tears down a cluster's client (if connected) and forgets it,
+// e.g. when its kubeconfig file disappears from disk.
+func (cs *ClusterSet) Remove(id ID) error {
+	cs.mu.Lock()
+	e, ok := cs.entries[id]
+	delete(cs.entries, id)
+	cs.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	client := e.client
+	e.client = nil
+	if client == nil {
+		return nil
+	}
+	if err := client.Close(); err != nil {
+		return fmt.Errorf("closing client for cluster %s: %w", id, err)
+	}
+	return nil
+}
+
+// IDs returns every cluster ID currently registered, connected or not.
+func (cs *ClusterSet) IDs() []ID {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	ids := make([]ID, 0, len(cs.entries))
+	for id := range cs.entries {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Spec returns the registered spec for id.
+func (cs *ClusterSet) Spec(id ID) (Spec, bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	e, ok := cs.entries[id]
+	if !ok {
+		return Spec{}, false
+	}
+	return e.spec, true
+}
+
+// Get returns the connected client for id, dialing the cluster's API
+// server on first use. The dial happens under id's own entry lock, not
+// ClusterSet's map lock, so a slow or unreachable cluster only blocks
+// concurrent Get calls for that same cluster, not for every other one.
+func (cs *ClusterSet) Get(ctx context.Context, id ID) (ClientInterface, error) {
+	cs.mu.Lock()
+	e, ok := cs.entries[id]
+	cs.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %s", id)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.client != nil {
+		return e.client, nil
+	}
+
+	if cs.tracer != nil {
+		var span trace.Span
+		ctx, span = cs.tracer.Start(ctx, "cluster.Dial", trace.WithAttributes(tracing.ClusterAttributes(e.spec.Context, e.spec.Namespace)...))
+		defer span.End()
+	}
+
+	client, err := FromKubeConfig(ctx, e.spec.KubeConfigPath, e.spec.Context, e.spec.Namespace, e.spec.Namespaces, cs.restConfigOptions)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to cluster %s (context %s): %w", id, e.spec.Context, err)
+	}
+
+	e.client = client
+	return e.client, nil
+}
+
+// DiscoverKubeConfigs expands a --kubeconfig value that may be a single
+// path, a comma-separated list of paths, or a directory containing one
+// kubeconfig file per cluster.
+func DiscoverKubeConfigs(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if info, err := os.Stat(value); err == nil && info.IsDir() {
+		files, err := ioutil.ReadDir(value)
+		if err != nil {
+			return nil, fmt.Errorf("reading kubeconfig directory %s: %w", value, err)
+		}
+
+		var paths []string
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			paths = append(paths, filepath.Join(value, f.Name()))
+		}
+		return paths, nil
+	}
+
+	parts := strings.Split(value, ",")
+	paths := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cluster
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDFor_stable(t *testing.T) {
+	a := IDFor("/tmp/kubeconfig", "dev")
+	b := IDFor("/tmp/kubeconfig", "dev")
+	c := IDFor("/tmp/kubeconfig", "prod")
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestDiscoverKubeConfigs_commaList(t *testing.T) {
+	paths, err := DiscoverKubeConfigs("/tmp/a, /tmp/b,/tmp/c")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tmp/a", "/tmp/b", "/tmp/c"}, paths)
+}
+
+func TestDiscoverKubeConfigs_directory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cluster-a"), []byte(""), 0o600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cluster-b"), []byte(""), 0o600))
+
+	paths, err := DiscoverKubeConfigs(dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "cluster-a"),
+		filepath.Join(dir, "cluster-b"),
+	}, paths)
+}
+
+func TestDiscoverKubeConfigs_empty(t *testing.T) {
+	paths, err := DiscoverKubeConfigs("")
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package observability gives operators running Octant as a shared
+// in-cluster service the health probes and metrics they'd expect from
+// any Kubernetes workload: /healthz, /readyz, /livez, /metrics, and
+// (optionally) /debug/pprof.
+package observability
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Component is one precondition that must hold before Octant reports
+// itself ready, e.g. "objectstore", "modules", "pluginManager".
+type Component string
+
+const (
+	ComponentObjectStore   Component = "objectstore"
+	ComponentPortForwarder Component = "portforwarder"
+	ComponentModules       Component = "modules"
+	ComponentPluginManager Component = "pluginManager"
+)
+
+// Checker tracks the readiness of each Component Octant's Runner
+// registers, plus a heartbeat Ping used to report liveness.
+type Checker struct {
+	mu         sync.Mutex
+	required   map[Component]bool
+	lastPingAt time.Time
+}
+
+// NewChecker creates a Checker that is not ready until every component
+// in required has been marked ready at least once.
+func NewChecker(required ...Component) *Checker {
+	c := &Checker{
+		required:   make(map[Component]bool, len(required)),
+		lastPingAt: time.Now(),
+	}
+	for _, r := range required {
+		c.required[r] = false
+	}
+	return c
+}
+
+// MarkReady records that component has finished initializing.
+func (c *Checker) MarkReady(component Component) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.required[component] = true
+}
+
+// Ready reports whether every required component has been marked ready.
+func (c *Checker) Ready() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, ready := range c.required {
+		if !ready {
+			return false
+		}
+	}
+	return true
+}
+
+// Ping records a liveness heartbeat from a background goroutine (e.g.
+// the websocket dispatcher, the informer resync loop).
+func (c *Checker) Ping() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastPingAt = time.Now()
+}
+
+// Alive reports whether a Ping has been recorded within staleAfter,
+// i.e. whether Octant's background goroutines are still making
+// progress.
+func (c *Checker) Alive(staleAfter time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Since(c.lastPingAt) < staleAfter
+}
+
+// HealthzHandler always reports 200 once the process is up; it answers
+// "is this process alive enough to receive traffic at all", not "is it
+// ready to serve requests".
+func HealthzHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+// ReadyzHandler reports 200 once every component Checker tracks has
+// been marked ready, and 503 otherwise.
+func ReadyzHandler(checker *Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checker.Ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+// LivezHandler reports 200 as long as Checker has seen a heartbeat
+// within the last minute, and 503 otherwise, so an operator's liveness
+// probe can restart a process whose background goroutines have wedged.
+func LivezHandler(checker *Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checker.Alive(time.Minute) {
+			http.Error(w, "stale", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	checker := NewChecker(ComponentObjectStore, ComponentModules)
+	assert.False(t, checker.Ready())
+
+	checker.MarkReady(ComponentObjectStore)
+	assert.False(t, checker.Ready())
+
+	checker.MarkReady(ComponentModules)
+	assert.True(t, checker.Ready())
+}
+
+func TestChecker_Alive(t *testing.T) {
+	checker := NewChecker()
+	assert.True(t, checker.Alive(time.Minute))
+
+	checker.lastPingAt = time.Now().Add(-2 * time.Minute)
+	assert.False(t, checker.Alive(time.Minute))
+
+	checker.Ping()
+	assert.True(t, checker.Alive(time.Minute))
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics is the shared Prometheus registry Octant instruments its key
+// call sites with: object store/port forwarder initialization, module
+// registration, plugin RPC round trips, and websocket message handling.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	InitDuration      *prometheus.HistogramVec
+	ModulesRegistered *prometheus.CounterVec
+	PluginRPCDuration *prometheus.HistogramVec
+	PluginRPCErrors   *prometheus.CounterVec
+	WebsocketMessages *prometheus.CounterVec
+	WebsocketDuration *prometheus.HistogramVec
+}
+
+// NewMetrics creates a Metrics registry with Octant's collectors
+// registered, ready to be exposed on /metrics.
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		Registry: registry,
+		InitDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "octant",
+			Name:      "init_duration_seconds",
+			Help:      "Time to initialize an Octant subsystem (object store, port forwarder, ...).",
+		}, []string{"subsystem"}),
+		ModulesRegistered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "octant",
+			Name:      "modules_registered_total",
+			Help:      "Number of modules successfully registered with the module manager.",
+		}, []string{"module"}),
+		PluginRPCDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "octant",
+			Name:      "plugin_rpc_duration_seconds",
+			Help:      "Duration of gRPC round trips to plugins.",
+		}, []string{"plugin", "method"}),
+		PluginRPCErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "octant",
+			Name:      "plugin_rpc_errors_total",
+			Help:      "Number of failed gRPC round trips to plugins.",
+		}, []string{"plugin", "method"}),
+		WebsocketMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "octant",
+			Name:      "websocket_messages_total",
+			Help:      "Number of websocket messages handled, by message type.",
+		}, []string{"type"}),
+		WebsocketDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "octant",
+			Name:      "websocket_message_duration_seconds",
+			Help:      "Time spent handling a websocket message, by message type.",
+		}, []string{"type"}),
+	}
+
+	registry.MustRegister(
+		m.InitDuration,
+		m.ModulesRegistered,
+		m.PluginRPCDuration,
+		m.PluginRPCErrors,
+		m.WebsocketMessages,
+		m.WebsocketDuration,
+	)
+
+	return m
+}
+
+// ObserveInit records how long an initialization call site (e.g.
+// initObjectStore, initPortForwarder) took.
+func (m *Metrics) ObserveInit(subsystem string, seconds float64) {
+	m.InitDuration.WithLabelValues(subsystem).Observe(seconds)
+}
+
+// ObserveModuleRegistered records that a module finished registering.
+func (m *Metrics) ObserveModuleRegistered(module string) {
+	m.ModulesRegistered.WithLabelValues(module).Inc()
+}
+
+// ObservePluginRPC records the outcome and duration of one plugin gRPC
+// round trip.
+func (m *Metrics) ObservePluginRPC(plugin, method string, seconds float64, err error) {
+	m.PluginRPCDuration.WithLabelValues(plugin, method).Observe(seconds)
+	if err != nil {
+		m.PluginRPCErrors.WithLabelValues(plugin, method).Inc()
+	}
+}
+
+// ObserveWebsocketMessage records the type and handling duration of one
+// inbound websocket message.
+func (m *Metrics) ObserveWebsocketMessage(msgType string, seconds float64) {
+	m.WebsocketMessages.WithLabelValues(msgType).Inc()
+	m.WebsocketDuration.WithLabelValues(msgType).Observe(seconds)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/vmware-tanzu/octant/pkg/log"
+)
+
+// Server is the small HTTP mux bound to --diagnostics-addr that exposes
+// Octant's health probes and metrics, separately from the dashboard's
+// own listener so it can be restricted to localhost or a cluster-only
+// network policy independent of the UI/API.
+type Server struct {
+	addr     string
+	checker  *Checker
+	metrics  *Metrics
+	logger   log.Logger
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewServer builds (but does not start) the diagnostics server. Passing
+// enablePprof registers /debug/pprof/* for live profiling; it should
+// default to off since pprof can leak process memory layout details.
+func NewServer(addr string, checker *Checker, metrics *Metrics, logger log.Logger, enablePprof bool) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", HealthzHandler())
+	mux.Handle("/readyz", ReadyzHandler(checker))
+	mux.Handle("/livez", LivezHandler(checker))
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+
+	if enablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	return &Server{
+		addr:    addr,
+		checker: checker,
+		metrics: metrics,
+		logger:  logger,
+		server:  &http.Server{Handler: mux},
+	}
+}
+
+// Start opens the diagnostics listener and begins serving in the
+// background. It returns once the listener is open so callers know the
+// address is bound before relying on it.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("opening diagnostics listener on %s: %w", s.addr, err)
+	}
+	s.listener = listener
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.logger.Errorf("diagnostics server: %v", err)
+		}
+	}()
+
+	s.logger.With("addr", listener.Addr().String()).Debugf("diagnostics endpoints available")
+
+	return nil
+}
+
+// Shutdown gracefully stops the diagnostics server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.server.Shutdown(shutdownCtx)
+}
+
+// WaitReady blocks until checker reports ready or ctx is done.
+func (s *Server) WaitReady(ctx context.Context) error {
+	ticker := time.NewTicker(25 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if s.checker.Ready() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ClusterAttributes returns the span attributes Octant attaches to spans
+// for work against a given cluster context and namespace: the
+// cluster.Dial and cluster.HealthCheck spans.
+func ClusterAttributes(clusterContext, namespace string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 2)
+	if clusterContext != "" {
+		attrs = append(attrs, attribute.String("octant.cluster.context", clusterContext))
+	}
+	if namespace != "" {
+		attrs = append(attrs, attribute.String("octant.cluster.namespace", namespace))
+	}
+	return attrs
+}
+
+// GVKAttributes returns the span attributes describing the resource kind
+// being operated on, for describer and object store spans.
+func GVKAttributes(gvk schema.GroupVersionKind) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("octant.resource.group", gvk.Group),
+		attribute.String("octant.resource.version", gvk.Version),
+		attribute.String("octant.resource.kind", gvk.Kind),
+	}
+}
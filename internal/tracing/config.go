@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+// Config describes how Octant should export distributed traces. It is
+// populated from viper (flags/env) and passed to Setup.
+type Config struct {
+	// Exporter selects the trace backend: "jaeger", "otlp", "stdout", or
+	// "none". An empty value is treated as "none".
+	Exporter string
+
+	// Endpoint is the exporter-specific destination, e.g. a Jaeger agent
+	// address (host:port) or an OTLP/gRPC collector address.
+	Endpoint string
+
+	// Headers are additional metadata sent with every export request,
+	// e.g. for collectors that require an API key.
+	Headers map[string]string
+
+	// ServiceName identifies this process in the trace backend.
+	ServiceName string
+
+	// SamplerType selects the sampling strategy: "always", "never",
+	// "probabilistic", or "parentbased".
+	SamplerType string
+
+	// SamplerArg is the sampler parameter, e.g. the sampling ratio used
+	// by the "probabilistic" sampler.
+	SamplerArg float64
+
+	// ResourceAttrs are additional OpenTelemetry resource attributes
+	// attached to every span, e.g. cluster or environment labels.
+	ResourceAttrs map[string]string
+
+	// Insecure disables TLS on the OTLP/gRPC exporter, for pushing to a
+	// plaintext in-cluster collector (e.g. an otel-collector or Tempo
+	// instance listening on :4317 without TLS). It has no effect on the
+	// other exporters.
+	Insecure bool
+}
+
+const (
+	ExporterJaeger = "jaeger"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterNone   = "none"
+
+	SamplerAlways        = "always"
+	SamplerNever         = "never"
+	SamplerProbabilistic = "probabilistic"
+	SamplerParentBased   = "parentbased"
+)
+
+// DefaultConfig returns the tracing configuration used when the operator
+// has not set any tracing flags.
+func DefaultConfig() Config {
+	return Config{
+		Exporter:    ExporterNone,
+		ServiceName: "octant",
+		SamplerType: SamplerParentBased,
+		SamplerArg:  1.0,
+	}
+}
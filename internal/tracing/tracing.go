@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tracing wires Octant's components into an OpenTelemetry
+// TracerProvider. It replaces the hard-coded OpenCensus/Jaeger setup that
+// used to live in pkg/dash with a configurable subsystem that can export
+// to Jaeger, any OTLP/gRPC collector (Tempo, Honeycomb, etc.), or stdout.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/exporters/trace/jaeger"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/vmware-tanzu/octant/pkg/log"
+)
+
+// Provider wraps the OpenTelemetry TracerProvider that Octant installs as
+// the process-global provider, plus a Shutdown hook callers must invoke
+// when the Runner stops so buffered spans are flushed.
+type Provider struct {
+	tp       *sdktrace.TracerProvider
+	Tracer   trace.Tracer
+	Shutdown func(ctx context.Context) error
+}
+
+// Setup builds a Provider from cfg and registers it as the global
+// OpenTelemetry TracerProvider. Exporter == "none" (or unset) returns a
+// no-op Provider so call sites can unconditionally create spans.
+func Setup(ctx context.Context, cfg Config, logger log.Logger) (*Provider, error) {
+	if cfg.Exporter == "" {
+		cfg.Exporter = ExporterNone
+	}
+
+	if cfg.Exporter == ExporterNone {
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()))
+		otel.SetTracerProvider(tp)
+		return &Provider{
+			tp:       tp,
+			Tracer:   tp.Tracer("octant"),
+			Shutdown: tp.Shutdown,
+		}, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := newResource(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("building trace resource: %w", err)
+	}
+
+	sampler, err := newSampler(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuring trace sampler: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
+	)
+	otel.SetTracerProvider(tp)
+
+	logger.With("exporter", cfg.Exporter, "endpoint", cfg.Endpoint).Infof("tracing enabled")
+
+	return &Provider{
+		tp:       tp,
+		Tracer:   tp.Tracer("octant"),
+		Shutdown: tp.Shutdown,
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterJaeger:
+		agentOpts := []jaeger.AgentEndpointOption{jaeger.WithAgentHost(cfg.Endpoint)}
+		if host, port, err := net.SplitHostPort(cfg.Endpoint); err == nil {
+			agentOpts = []jaeger.AgentEndpointOption{jaeger.WithAgentHost(host), jaeger.WithAgentPort(port)}
+		}
+		return jaeger.New(jaeger.WithAgentEndpoint(agentOpts...))
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.Endpoint),
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+		}
+		client := otlptracegrpc.NewClient(opts...)
+		return otlptrace.New(ctx, client)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+}
+
+func newResource(ctx context.Context, cfg Config) (*resource.Resource, error) {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "octant"
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(serviceName)}
+	for k, v := range cfg.ResourceAttrs {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+
+	return resource.New(ctx, resource.WithAttributes(attrs...))
+}
+
+func newSampler(cfg Config) (sdktrace.Sampler, error) {
+	switch cfg.SamplerType {
+	case "", SamplerParentBased:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case SamplerAlways:
+		return sdktrace.AlwaysSample(), nil
+	case SamplerNever:
+		return sdktrace.NeverSample(), nil
+	case SamplerProbabilistic:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplerArg)), nil
+	default:
+		return nil, fmt.Errorf("unknown sampler type %q", cfg.SamplerType)
+	}
+}
@@ -7,18 +7,29 @@ package dash
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/skratchdot/open-golang/open"
 	"github.com/spf13/viper"
-	"go.opencensus.io/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/vmware-tanzu/octant/internal/api"
 	"github.com/vmware-tanzu/octant/internal/cluster"
@@ -34,20 +45,24 @@ import (
 	"github.com/vmware-tanzu/octant/internal/modules/overview"
 	"github.com/vmware-tanzu/octant/internal/modules/workloads"
 	"github.com/vmware-tanzu/octant/internal/objectstore"
+	"github.com/vmware-tanzu/octant/internal/observability"
 	"github.com/vmware-tanzu/octant/internal/portforward"
+	"github.com/vmware-tanzu/octant/internal/tracing"
 	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/log"
 	"github.com/vmware-tanzu/octant/pkg/octant"
 	"github.com/vmware-tanzu/octant/pkg/plugin"
 	pluginAPI "github.com/vmware-tanzu/octant/pkg/plugin/api"
+	"github.com/vmware-tanzu/octant/pkg/plugin/registry"
 	"github.com/vmware-tanzu/octant/pkg/store"
 	"github.com/vmware-tanzu/octant/web"
 )
 
 type Options struct {
-	EnableOpenCensus       bool
+	TracingConfig          tracing.Config
 	DisableClusterOverview bool
 	KubeConfig             string
+	Contexts               []string
 	Namespace              string
 	Namespaces             []string
 	FrontendURL            string
@@ -57,132 +72,184 @@ type Options struct {
 	ClientBurst            int
 	UserAgent              string
 	BuildInfo              config.BuildInfo
+
+	TLSCertFile         string
+	TLSKeyFile          string
+	TLSClientCAFile     string
+	TLSMinVersion       string
+	AutoTLS             bool
+	TrustedProxyHeaders bool
+
+	DiagnosticsAddr string
+	EnablePprof     bool
+}
+
+// clusterStack bundles the per-cluster components the Runner needs: one
+// per entry in the ClusterSet, built eagerly for the initial/active
+// cluster and lazily for any others on first use.
+type clusterStack struct {
+	client              cluster.ClientInterface
+	objectStore         store.Store
+	errorStore          oerrors.ErrorStore
+	crdWatcher          config.CRDWatcher
+	portForwarder       portforward.PortForwarder
+	moduleManager       *module.Manager
+	dashConfig          config.Dash
+	pluginFrontendProxy pluginAPI.FrontendProxy
+
+	// ctx is scoped to this stack alone (a child of the Runner's ctx), so
+	// cancel stops its CRD watcher and port forwarder without tearing
+	// down any other cluster's stack.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 type Runner struct {
-	dash          *dash
-	pluginManager *plugin.Manager
-	moduleManager *module.Manager
+	dash            *dash
+	pluginManager   *plugin.Manager
+	pluginRegistry  *registry.Registry
+	moduleManager   *module.Manager
+	tracingShutdown func(ctx context.Context) error
+
+	clusterSet      *cluster.ClusterSet
+	clusterSwitcher *api.ClusterSwitcher
+	activeClusterID cluster.ID
+
+	stacksMu sync.Mutex
+	stacks   map[cluster.ID]*clusterStack
+
+	logger            log.Logger
+	actionManager     *action.Manager
+	tracer            trace.Tracer
+	buildInfo         config.BuildInfo
+	restConfigOptions cluster.RESTConfigOptions
+
+	observabilityServer *observability.Server
+	healthChecker       *observability.Checker
+	metrics             *observability.Metrics
 }
 
 func NewRunner(ctx context.Context, logger log.Logger, options Options) (*Runner, error) {
 	ctx = internalLog.WithLoggerContext(ctx, logger)
 
-	r := Runner{}
+	r := Runner{
+		stacks:        make(map[cluster.ID]*clusterStack),
+		logger:        logger,
+		actionManager: action.NewManager(logger),
+		healthChecker: observability.NewChecker(
+			observability.ComponentObjectStore,
+			observability.ComponentPortForwarder,
+			observability.ComponentModules,
+			observability.ComponentPluginManager,
+		),
+		metrics: observability.NewMetrics(),
+	}
+
+	diagnosticsAddr := options.DiagnosticsAddr
+	if diagnosticsAddr == "" {
+		diagnosticsAddr = diagnosticsAddrFromViper()
+	}
+	r.observabilityServer = observability.NewServer(diagnosticsAddr, r.healthChecker, r.metrics, logger, options.EnablePprof)
+	if err := r.observabilityServer.Start(ctx); err != nil {
+		return nil, fmt.Errorf("starting diagnostics server: %w", err)
+	}
 
 	if options.Context != "" {
 		logger.With("initial-context", options.Context).Infof("Setting initial context from user flags")
 	}
 
+	tracingConfig := options.TracingConfig
+	if tracingConfig.Exporter == "" {
+		tracingConfig = tracingConfigFromViper()
+	}
+
+	tracingProvider, err := tracing.Setup(ctx, tracingConfig, logger)
+	if err != nil {
+		return nil, fmt.Errorf("setting up tracing: %w", err)
+	}
+	r.tracingShutdown = tracingProvider.Shutdown
+	r.tracer = tracingProvider.Tracer
+
 	logger.Debugf("Loading configuration: %v", options.KubeConfig)
 	restConfigOptions := cluster.RESTConfigOptions{
 		QPS:       options.ClientQPS,
 		Burst:     options.ClientBurst,
 		UserAgent: options.UserAgent,
 	}
-	clusterClient, err := cluster.FromKubeConfig(ctx, options.KubeConfig, options.Context, options.Namespace, options.Namespaces, restConfigOptions)
+	r.restConfigOptions = restConfigOptions
+	r.clusterSet = cluster.NewClusterSet(restConfigOptions, r.tracer)
+
+	kubeConfigPaths, err := cluster.DiscoverKubeConfigs(options.KubeConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to init cluster client: %w", err)
+		return nil, fmt.Errorf("discovering kubeconfigs in %s: %w", options.KubeConfig, err)
+	}
+	if len(kubeConfigPaths) == 0 {
+		kubeConfigPaths = []string{options.KubeConfig}
 	}
 
-	if options.EnableOpenCensus {
-		if err := enableOpenCensus(); err != nil {
-			logger.Infof("Enabling OpenCensus")
-			return nil, fmt.Errorf("enabling open census: %w", err)
-		}
+	contexts := options.Contexts
+	if len(contexts) == 0 {
+		contexts = []string{options.Context}
 	}
 
-	nsClient, err := clusterClient.NamespaceClient()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create namespace client: %w", err)
+	for _, kubeConfigPath := range kubeConfigPaths {
+		for _, clusterContext := range contexts {
+			spec := cluster.Spec{
+				KubeConfigPath: kubeConfigPath,
+				Context:        clusterContext,
+				Namespace:      options.Namespace,
+				Namespaces:     options.Namespaces,
+			}
+			id := r.clusterSet.Add(spec)
+			if r.activeClusterID == "" {
+				r.activeClusterID = id
+			}
+		}
 	}
 
-	// If not overridden, use initial namespace from current context in KUBECONFIG
-	if options.Namespace == "" {
-		options.Namespace = nsClient.InitialNamespace()
+	r.buildInfo = config.BuildInfo{
+		Version: options.BuildInfo.Version,
+		Commit:  options.BuildInfo.Commit,
+		Time:    options.BuildInfo.Time,
 	}
 
-	logger.Debugf("initial namespace for dashboard is %s", options.Namespace)
+	r.clusterSwitcher = api.NewClusterSwitcher(r.clusterSet, r.activeClusterID, nil, r.tracer, r.resolveStack)
 
-	appObjectStore, err := initObjectStore(ctx, clusterClient)
+	stack, err := r.buildClusterStack(ctx, r.activeClusterID)
 	if err != nil {
-		return nil, fmt.Errorf("initializing store: %w", err)
+		return nil, fmt.Errorf("initializing cluster %s: %w", r.activeClusterID, err)
 	}
 
-	errorStore, err := oerrors.NewErrorStore()
-	if err != nil {
-		return nil, fmt.Errorf("initializing error store: %w", err)
-	}
+	r.moduleManager = stack.moduleManager
 
-	crdWatcher, err := describer.NewDefaultCRDWatcher(ctx, clusterClient, appObjectStore, errorStore)
+	registryBaseDir, err := registry.DefaultBaseDir()
 	if err != nil {
-		var ae *oerrors.AccessError
-		if errors.As(err, &ae) {
-			if ae.Name() == oerrors.OctantAccessError {
-				logger.Warnf("skipping CRD watcher due to access denied error starting watcher")
-			}
-		} else {
-			return nil, fmt.Errorf("initializing CRD watcher: %w", err)
-		}
+		return nil, fmt.Errorf("resolving plugin registry directory: %w", err)
 	}
-
-	portForwarder, err := initPortForwarder(ctx, clusterClient, appObjectStore)
+	pluginRegistry, err := registry.NewRegistry(registryBaseDir, logger, supportsOctantVersion(r.buildInfo.Version))
 	if err != nil {
-		return nil, fmt.Errorf("initializing port forwarder: %w", err)
+		return nil, fmt.Errorf("initializing plugin registry: %w", err)
 	}
+	r.pluginRegistry = pluginRegistry
 
-	actionManger := action.NewManager(logger)
-
-	mo := &moduleOptions{
-		clusterClient: clusterClient,
-		namespace:     options.Namespace,
-		logger:        logger,
-		actionManager: actionManger,
+	if err := r.actionManager.Register(registry.InstallActionName, registry.InstallAction(pluginRegistry)); err != nil {
+		return nil, fmt.Errorf("registering %s action: %w", registry.InstallActionName, err)
 	}
-	moduleManager, err := initModuleManager(mo)
-	if err != nil {
-		return nil, fmt.Errorf("init module manager: %w", err)
-	}
-
-	r.moduleManager = moduleManager
 
-	frontendProxy := pluginAPI.FrontendProxy{}
-
-	pluginDashboardService := &pluginAPI.GRPCService{
-		ObjectStore:        appObjectStore,
-		PortForwarder:      portForwarder,
-		NamespaceInterface: nsClient,
-		FrontendProxy:      frontendProxy,
+	registryPluginPaths, err := resolveEnabledPlugins(pluginRegistry, logger)
+	if err != nil {
+		return nil, fmt.Errorf("resolving registry plugins: %w", err)
 	}
 
-	pluginManager, err := initPlugin(moduleManager, actionManger, pluginDashboardService)
+	pluginManager, err := r.initPluginManager(ctx, stack, registryPluginPaths)
 	if err != nil {
 		return nil, fmt.Errorf("initializing plugin manager: %w", err)
 	}
-
 	r.pluginManager = pluginManager
 
-	buildInfo := config.BuildInfo{
-		Version: options.BuildInfo.Version,
-		Commit:  options.BuildInfo.Commit,
-		Time:    options.BuildInfo.Time,
-	}
+	dashConfig := stack.dashConfig
 
-	dashConfig := config.NewLiveConfig(
-		clusterClient,
-		crdWatcher,
-		options.KubeConfig,
-		logger,
-		moduleManager,
-		appObjectStore,
-		errorStore,
-		pluginManager,
-		portForwarder,
-		options.Context,
-		restConfigOptions,
-		buildInfo)
-
-	if err := watchConfigs(ctx, dashConfig, options.KubeConfig); err != nil {
+	if err := watchConfigs(ctx, dashConfig, options.KubeConfig, r.RemoveCluster); err != nil {
 		return nil, fmt.Errorf("set up config watcher: %w", err)
 	}
 
@@ -192,27 +259,37 @@ func NewRunner(ctx context.Context, logger log.Logger, options Options) (*Runner
 	}
 
 	for _, mod := range moduleList {
-		if err := moduleManager.Register(mod); err != nil {
+		if err := stack.moduleManager.Register(mod); err != nil {
 			return nil, fmt.Errorf("loading module %s: %w", mod.Name(), err)
 		}
+		r.metrics.ObserveModuleRegistered(mod.Name())
 	}
+	r.healthChecker.MarkReady(observability.ComponentModules)
 
 	if err := pluginManager.Start(ctx); err != nil {
 		return nil, fmt.Errorf("start plugin manager: %w", err)
 	}
+	r.healthChecker.MarkReady(observability.ComponentPluginManager)
+
+	tlsOptions := tlsOptionsFromViper(options)
 
-	listener, err := buildListener()
+	tlsConfig, err := buildTLSConfig(tlsOptions, logger)
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS: %w", err)
+	}
+
+	listener, err := buildListener(tlsConfig)
 	if err != nil {
 		err = fmt.Errorf("failed to create net listener: %w", err)
 		return nil, fmt.Errorf("use OCTANT_LISTENER_ADDR to set host:port: %w", err)
 	}
 
 	// Initialize the API
-	apiService := api.New(ctx, api.PathPrefix, actionManger, dashConfig)
-	frontendProxy.FrontendUpdateController = apiService
+	apiService := api.New(ctx, api.PathPrefix, r.actionManager, dashConfig, r.tracer, r.clusterSwitcher, r.metrics)
+	stack.pluginFrontendProxy.FrontendUpdateController = apiService
 
 	// Watch for CRDs after modules initialized
-	if err := crdWatcher.Watch(ctx); err != nil {
+	if err := stack.crdWatcher.Watch(stack.ctx); err != nil {
 		return nil, fmt.Errorf("unable to start CRD watcher: %w", err)
 	}
 
@@ -220,6 +297,9 @@ func NewRunner(ctx context.Context, logger log.Logger, options Options) (*Runner
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dash instance: %w", err)
 	}
+	d.tlsEnabled = tlsConfig != nil
+	d.mtlsEnabled = tlsConfig != nil && tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert
+	d.trustedProxyHeaders = tlsOptions.TrustedProxyHeaders
 
 	if viper.GetBool("disable-open-browser") {
 		d.willOpenBrowser = false
@@ -233,12 +313,40 @@ func NewRunner(ctx context.Context, logger log.Logger, options Options) (*Runner
 func (r *Runner) Start(ctx context.Context, startupCh, shutdownCh chan bool) {
 	logger := internalLog.From(ctx)
 
+	listenerUpCh := make(chan bool, 1)
+
 	go func() {
-		if err := r.dash.Run(ctx, startupCh); err != nil {
+		if err := r.dash.Run(ctx, listenerUpCh); err != nil {
 			logger.Debugf("running dashboard service: %v", err)
 		}
 	}()
 
+	go func() {
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.healthChecker.Ping()
+			}
+		}
+	}()
+
+	if startupCh != nil {
+		go func() {
+			<-listenerUpCh
+
+			if err := r.observabilityServer.WaitReady(ctx); err != nil {
+				logger.Debugf("waiting for readiness: %v", err)
+				return
+			}
+
+			startupCh <- true
+		}()
+	}
+
 	<-ctx.Done()
 
 	shutdownCtx := internalLog.WithLoggerContext(context.Background(), logger)
@@ -246,9 +354,297 @@ func (r *Runner) Start(ctx context.Context, startupCh, shutdownCh chan bool) {
 	r.moduleManager.Unload()
 	r.pluginManager.Stop(shutdownCtx)
 
+	if err := r.observabilityServer.Shutdown(shutdownCtx); err != nil {
+		logger.Debugf("shutting down diagnostics server: %v", err)
+	}
+
+	if r.tracingShutdown != nil {
+		if err := r.tracingShutdown(shutdownCtx); err != nil {
+			logger.Debugf("shutting down tracer provider: %v", err)
+		}
+	}
+
 	shutdownCh <- true
 }
 
+// Stack returns the per-cluster stack for id, building it on first use so
+// a cluster's informers etc. are only spun up once a describer, module,
+// or the cluster switcher actually needs it. The active cluster's stack
+// is always already built by NewRunner.
+func (r *Runner) Stack(ctx context.Context, id cluster.ID) (*clusterStack, error) {
+	r.stacksMu.Lock()
+	defer r.stacksMu.Unlock()
+
+	if s, ok := r.stacks[id]; ok {
+		return s, nil
+	}
+
+	return r.buildClusterStack(ctx, id)
+}
+
+// RemoveCluster tears down cluster id's stack, if one has been built
+// (cancelling its CRD watcher and port forwarder and closing its client),
+// and forgets the cluster entirely. It is safe to call for a cluster that
+// was never connected, e.g. one whose kubeconfig disappeared before
+// anything used it.
+func (r *Runner) RemoveCluster(id cluster.ID) {
+	r.stacksMu.Lock()
+	stack, ok := r.stacks[id]
+	delete(r.stacks, id)
+	r.stacksMu.Unlock()
+
+	if ok {
+		stack.cancel()
+	}
+
+	if err := r.clusterSet.Remove(id); err != nil {
+		r.logger.With("cluster", id).Errorf("removing cluster: %v", err)
+	}
+}
+
+// resolveStack implements api.StackResolver, giving the cluster switcher
+// a way to turn a session's active cluster ID into that cluster's live
+// dashboard config.
+func (r *Runner) resolveStack(ctx context.Context, id cluster.ID) (config.Dash, error) {
+	stack, err := r.Stack(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return stack.dashConfig, nil
+}
+
+// buildClusterStack connects to cluster id (lazily, via the ClusterSet)
+// and constructs its object store, port forwarder, module manager, and
+// config.Dash. The very first stack, built from NewRunner before the
+// plugin manager exists, gets its config.Dash from initPluginManager
+// instead. Callers must hold stacksMu, except for that first call, made
+// before concurrent access is possible.
+func (r *Runner) buildClusterStack(ctx context.Context, id cluster.ID) (*clusterStack, error) {
+	spec, ok := r.clusterSet.Spec(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster %s", id)
+	}
+
+	stackCtx, cancel := context.WithCancel(ctx)
+	ok = false
+	defer func() {
+		if !ok {
+			cancel()
+		}
+	}()
+
+	clusterClient, err := r.clusterSet.Get(stackCtx, id)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to cluster: %w", err)
+	}
+
+	nsClient, err := clusterClient.NamespaceClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create namespace client: %w", err)
+	}
+
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = nsClient.InitialNamespace()
+	}
+
+	r.logger.Debugf("initial namespace for cluster %s is %s", id, namespace)
+
+	objectStoreStart := time.Now()
+	appObjectStore, err := initObjectStore(stackCtx, clusterClient)
+	if err != nil {
+		return nil, fmt.Errorf("initializing store: %w", err)
+	}
+	r.metrics.ObserveInit("objectstore", time.Since(objectStoreStart).Seconds())
+	r.healthChecker.MarkReady(observability.ComponentObjectStore)
+
+	errorStore, err := oerrors.NewErrorStore()
+	if err != nil {
+		return nil, fmt.Errorf("initializing error store: %w", err)
+	}
+
+	crdWatcher, err := describer.NewDefaultCRDWatcher(stackCtx, clusterClient, appObjectStore, errorStore)
+	if err != nil {
+		var ae *oerrors.AccessError
+		if errors.As(err, &ae) {
+			if ae.Name() == oerrors.OctantAccessError {
+				r.logger.Warnf("skipping CRD watcher due to access denied error starting watcher")
+			}
+		} else {
+			return nil, fmt.Errorf("initializing CRD watcher: %w", err)
+		}
+	}
+
+	portForwarderStart := time.Now()
+	portForwarder, err := initPortForwarder(stackCtx, clusterClient, appObjectStore)
+	if err != nil {
+		return nil, fmt.Errorf("initializing port forwarder: %w", err)
+	}
+	r.metrics.ObserveInit("portforwarder", time.Since(portForwarderStart).Seconds())
+	r.healthChecker.MarkReady(observability.ComponentPortForwarder)
+
+	mo := &moduleOptions{
+		clusterClient: clusterClient,
+		namespace:     namespace,
+		logger:        r.logger,
+		actionManager: r.actionManager,
+	}
+	moduleManager, err := initModuleManager(mo, r.tracer)
+	if err != nil {
+		return nil, fmt.Errorf("init module manager: %w", err)
+	}
+
+	stack := &clusterStack{
+		client:        clusterClient,
+		objectStore:   appObjectStore,
+		errorStore:    errorStore,
+		crdWatcher:    crdWatcher,
+		portForwarder: portForwarder,
+		moduleManager: moduleManager,
+		ctx:           stackCtx,
+		cancel:        cancel,
+	}
+
+	if r.pluginManager != nil {
+		stack.dashConfig = config.NewLiveConfig(clusterClient, crdWatcher, spec.KubeConfigPath, r.logger,
+			moduleManager, appObjectStore, errorStore, r.pluginManager, portForwarder, spec.Context,
+			r.restConfigOptions, r.buildInfo, r.tracer)
+	}
+
+	r.stacks[id] = stack
+
+	ok = true
+	return stack, nil
+}
+
+// initPluginManager wires a plugin.Manager for stack's cluster. The
+// plugin manager itself is process-wide (plugins are not cluster
+// scoped), but it is created against the active cluster's stack so its
+// gRPC service has a dashboard API to call back into. registryPluginPaths
+// are the on-disk, digest-verified binaries of plugins installed through
+// the OCI registry; they are started alongside any plugins discovered
+// from the filesystem.
+func (r *Runner) initPluginManager(ctx context.Context, stack *clusterStack, registryPluginPaths []string) (*plugin.Manager, error) {
+	nsClient, err := stack.client.NamespaceClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create namespace client: %w", err)
+	}
+
+	pluginDashboardService := &pluginAPI.GRPCService{
+		ObjectStore:        stack.objectStore,
+		PortForwarder:      stack.portForwarder,
+		NamespaceInterface: nsClient,
+		FrontendProxy:      stack.pluginFrontendProxy,
+		Metrics:            r.metrics,
+	}
+
+	pluginManager, err := initPlugin(stack.moduleManager, r.actionManager, pluginDashboardService, registryPluginPaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	buildInfo := r.buildInfo
+
+	spec, _ := r.clusterSet.Spec(r.activeClusterID)
+
+	stack.dashConfig = config.NewLiveConfig(
+		stack.client,
+		stack.crdWatcher,
+		spec.KubeConfigPath,
+		r.logger,
+		stack.moduleManager,
+		stack.objectStore,
+		stack.errorStore,
+		pluginManager,
+		stack.portForwarder,
+		spec.Context,
+		r.restConfigOptions,
+		buildInfo,
+		r.tracer)
+
+	return pluginManager, nil
+}
+
+// resolveEnabledPlugins resolves every enabled ref known to reg to its
+// verified, on-disk binary path, so the caller can hand them to the
+// plugin manager alongside its filesystem-discovered plugins. A ref that
+// is disabled or fails its digest check is skipped with a warning rather
+// than failing startup, since a single bad registry plugin shouldn't
+// prevent Octant from starting.
+func resolveEnabledPlugins(reg *registry.Registry, logger log.Logger) ([]string, error) {
+	refs, err := reg.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing installed plugins: %w", err)
+	}
+
+	var paths []string
+	for _, ref := range refs {
+		path, err := reg.Resolve(ref)
+		if err != nil {
+			logger.With("ref", ref.String(), "err", err).Warnf("skipping registry plugin")
+			continue
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// supportsOctantVersion returns a registry.MinOctantVersionFunc comparing
+// a plugin's declared minimum Octant version against buildVersion. An
+// unparsable version on either side (e.g. a "dev" build) is treated as
+// compatible, since there is nothing meaningful to compare.
+func supportsOctantVersion(buildVersion string) registry.MinOctantVersionFunc {
+	return func(minVersion string) bool {
+		current, err := parseSemVer(buildVersion)
+		if err != nil {
+			return true
+		}
+		required, err := parseSemVer(minVersion)
+		if err != nil {
+			return true
+		}
+		return !current.lessThan(required)
+	}
+}
+
+type semVer struct {
+	major, minor, patch int
+}
+
+func (v semVer) lessThan(other semVer) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	return v.patch < other.patch
+}
+
+func parseSemVer(s string) (semVer, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	parts := strings.SplitN(s, ".", 3)
+	if len(parts) != 3 {
+		return semVer{}, fmt.Errorf("invalid version %q", s)
+	}
+
+	var v semVer
+	var err error
+	if v.major, err = strconv.Atoi(parts[0]); err != nil {
+		return semVer{}, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+	if v.minor, err = strconv.Atoi(parts[1]); err != nil {
+		return semVer{}, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+	patch := strings.SplitN(parts[2], "-", 2)[0]
+	if v.patch, err = strconv.Atoi(patch); err != nil {
+		return semVer{}, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+
+	return v, nil
+}
+
 // initObjectStore initializes the cluster object store interface
 func initObjectStore(ctx context.Context, client cluster.ClientInterface) (store.Store, error) {
 	if client == nil {
@@ -342,8 +738,8 @@ func initModules(ctx context.Context, dashConfig config.Dash, namespace string,
 }
 
 // initModuleManager initializes the moduleManager (and currently the modules themselves)
-func initModuleManager(options *moduleOptions) (*module.Manager, error) {
-	moduleManager, err := module.NewManager(options.clusterClient, options.namespace, options.actionManager, options.logger)
+func initModuleManager(options *moduleOptions, tracer trace.Tracer) (*module.Manager, error) {
+	moduleManager, err := module.NewManager(options.clusterClient, options.namespace, options.actionManager, options.logger, tracer)
 	if err != nil {
 		return nil, fmt.Errorf("create module manager: %w", err)
 	}
@@ -351,26 +747,253 @@ func initModuleManager(options *moduleOptions) (*module.Manager, error) {
 	return moduleManager, nil
 }
 
-func buildListener() (net.Listener, error) {
+// diagnosticsAddrFromViper returns the configured --diagnostics-addr, or
+// Octant's default of 127.0.0.1:7777.
+func diagnosticsAddrFromViper() string {
+	if addr := viper.GetString("diagnostics-addr"); addr != "" {
+		return addr
+	}
+	return "127.0.0.1:7777"
+}
+
+// tlsOptionsFromViper fills in any TLS-related Options fields left unset
+// by the caller from their corresponding flags/env.
+func tlsOptionsFromViper(options Options) Options {
+	if options.TLSCertFile == "" {
+		options.TLSCertFile = viper.GetString("tls-cert-file")
+	}
+	if options.TLSKeyFile == "" {
+		options.TLSKeyFile = viper.GetString("tls-key-file")
+	}
+	if options.TLSClientCAFile == "" {
+		options.TLSClientCAFile = viper.GetString("tls-client-ca-file")
+	}
+	if options.TLSMinVersion == "" {
+		options.TLSMinVersion = viper.GetString("tls-min-version")
+	}
+	if !options.AutoTLS {
+		options.AutoTLS = viper.GetBool("auto-tls")
+	}
+	if !options.TrustedProxyHeaders {
+		options.TrustedProxyHeaders = viper.GetBool("trusted-proxy-headers")
+	}
+
+	return options
+}
+
+// tracingConfigFromViper builds a tracing.Config from flags/env, falling
+// back to the legacy "enable-opencensus" flag (which now enables the
+// Jaeger exporter for backwards compatibility) when no exporter is set.
+func tracingConfigFromViper() tracing.Config {
+	cfg := tracing.DefaultConfig()
+
+	cfg.Exporter = viper.GetString("trace-exporter")
+	if cfg.Exporter == "" && viper.GetBool("enable-opencensus") {
+		cfg.Exporter = tracing.ExporterJaeger
+		cfg.Endpoint = "localhost:6831"
+	}
+
+	if endpoint := viper.GetString("trace-endpoint"); endpoint != "" {
+		cfg.Endpoint = endpoint
+	}
+	if headers := viper.GetStringMapString("trace-headers"); len(headers) > 0 {
+		cfg.Headers = headers
+	}
+	if serviceName := viper.GetString("trace-service-name"); serviceName != "" {
+		cfg.ServiceName = serviceName
+	}
+	if samplerType := viper.GetString("trace-sampler"); samplerType != "" {
+		cfg.SamplerType = samplerType
+	}
+	if viper.IsSet("trace-sampler-arg") {
+		cfg.SamplerArg = viper.GetFloat64("trace-sampler-arg")
+	}
+	if attrs := viper.GetStringMapString("trace-resource-attrs"); len(attrs) > 0 {
+		cfg.ResourceAttrs = attrs
+	}
+	if viper.IsSet("trace-insecure") {
+		cfg.Insecure = viper.GetBool("trace-insecure")
+	}
+
+	return cfg
+}
+
+// buildListener opens the dashboard's TCP listener and, when tlsConfig is
+// non-nil, wraps it so every accepted connection is transparently
+// TLS-terminated (including, when tlsConfig.ClientAuth is set, verifying
+// the client's certificate for mTLS).
+func buildListener(tlsConfig *tls.Config) (net.Listener, error) {
 	listenerAddr := api.ListenerAddr()
 	conn, err := net.DialTimeout("tcp", listenerAddr, time.Millisecond*500)
+	if err == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("tcp %s: dial: already in use", listenerAddr)
+	}
+
+	listener, err := net.Listen("tcp", listenerAddr)
 	if err != nil {
-		return net.Listen("tcp", listenerAddr)
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		return tls.NewListener(listener, tlsConfig), nil
 	}
-	_ = conn.Close()
-	return nil, fmt.Errorf("tcp %s: dial: already in use", listenerAddr)
+
+	return listener, nil
+}
+
+// buildTLSConfig returns nil when Octant should serve plain HTTP, or a
+// *tls.Config built from options.TLS* (or a generated AutoTLS
+// certificate) otherwise. When TLSClientCAFile is set, client
+// certificates are required and verified for mTLS.
+func buildTLSConfig(options Options, logger log.Logger) (*tls.Config, error) {
+	certFile, keyFile := options.TLSCertFile, options.TLSKeyFile
+
+	if certFile == "" && keyFile == "" {
+		if !options.AutoTLS {
+			return nil, nil
+		}
+
+		var err error
+		certFile, keyFile, err = ensureAutoTLSCert(logger)
+		if err != nil {
+			return nil, fmt.Errorf("generating AutoTLS certificate: %w", err)
+		}
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tlsVersionFromString(options.TLSMinVersion),
+	}
+
+	if options.TLSClientCAFile != "" {
+		caBytes, err := ioutil.ReadFile(options.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading client CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("no certificates found in %s", options.TLSClientCAFile)
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+func tlsVersionFromString(s string) uint16 {
+	switch s {
+	case "1.0":
+		return tls.VersionTLS10
+	case "1.1":
+		return tls.VersionTLS11
+	case "1.2":
+		return tls.VersionTLS12
+	case "1.3":
+		return tls.VersionTLS13
+	default:
+		return tls.VersionTLS12
+	}
+}
+
+// ensureAutoTLSCert returns the cert/key pair under Octant's config dir,
+// generating a self-signed one on first boot and reusing it thereafter.
+func ensureAutoTLSCert(logger log.Logger) (certFile, keyFile string, err error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", "", err
+	}
+
+	dir := filepath.Join(configDir, "octant")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	certFile = filepath.Join(dir, "autotls-cert.pem")
+	keyFile = filepath.Join(dir, "autotls-key.pem")
+
+	if _, err := os.Stat(certFile); err == nil {
+		if _, err := os.Stat(keyFile); err == nil {
+			return certFile, keyFile, nil
+		}
+	}
+
+	logger.Infof("generating self-signed AutoTLS certificate at %s", certFile)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generating TLS key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", fmt.Errorf("generating certificate serial: %w", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "octant"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", fmt.Errorf("creating certificate: %w", err)
+	}
+
+	certOut, err := os.OpenFile(certFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return "", "", err
+	}
+	defer certOut.Close()
+	if err := pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		return "", "", fmt.Errorf("writing certificate: %w", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("marshaling TLS key: %w", err)
+	}
+
+	keyOut, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", "", err
+	}
+	defer keyOut.Close()
+	if err := pem.Encode(keyOut, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}); err != nil {
+		return "", "", fmt.Errorf("writing key: %w", err)
+	}
+
+	return certFile, keyFile, nil
 }
 
 type dash struct {
-	listener        net.Listener
-	uiURL           string
-	browserPath     string
-	namespace       string
-	defaultHandler  func() (http.Handler, error)
-	apiHandler      api.Service
-	willOpenBrowser bool
-	logger          log.Logger
-	handlerFactory  *octant.HandlerFactory
+	listener            net.Listener
+	uiURL               string
+	browserPath         string
+	namespace           string
+	defaultHandler      func() (http.Handler, error)
+	apiHandler          api.Service
+	willOpenBrowser     bool
+	logger              log.Logger
+	handlerFactory      *octant.HandlerFactory
+	tlsEnabled          bool
+	mtlsEnabled         bool
+	trustedProxyHeaders bool
 }
 
 func newDash(listener net.Listener, namespace, uiURL string, browserPath string, apiHandler api.Service, logger log.Logger) (*dash, error) {
@@ -401,6 +1024,14 @@ func (d *dash) Run(ctx context.Context, startupCh chan bool) error {
 		return err
 	}
 
+	if d.mtlsEnabled {
+		handler = principalHandler(handler)
+	}
+
+	if d.trustedProxyHeaders {
+		handler = trustedProxyHandler(handler)
+	}
+
 	server := http.Server{Handler: handler}
 
 	go func() {
@@ -410,7 +1041,11 @@ func (d *dash) Run(ctx context.Context, startupCh chan bool) error {
 		}
 	}()
 
-	dashboardURL := fmt.Sprintf("http://%s", d.listener.Addr())
+	scheme := "http"
+	if d.tlsEnabled {
+		scheme = "https"
+	}
+	dashboardURL := fmt.Sprintf("%s://%s", scheme, d.listener.Addr())
 
 	d.logger.Infof("Dashboard is available at %s\n", dashboardURL)
 
@@ -439,22 +1074,56 @@ func (d *dash) Run(ctx context.Context, startupCh chan bool) error {
 	return server.Shutdown(shutdownCtx)
 }
 
-func enableOpenCensus() error {
-	agentEndpointURI := "localhost:6831"
+// principalContextKey is the context key under which an mTLS client
+// certificate's subject is stored, for modules/plugins that want to know
+// who is making the current request.
+type principalContextKey struct{}
+
+// PrincipalFromContext returns the authenticated mTLS client's common
+// name, if Octant is running with TLSClientCAFile set and the request
+// presented a verified client certificate.
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(string)
+	return principal, ok
+}
 
-	je, err := jaeger.NewExporter(jaeger.Options{
-		AgentEndpoint: agentEndpointURI,
-		Process: jaeger.Process{
-			ServiceName: "octant",
-		},
-	})
+// principalHandler is installed whenever the listener requires and verifies
+// a client certificate (TLSClientCAFile is set), independent of whether
+// Octant also sits behind a trusted reverse proxy. It extracts the verified
+// peer certificate's common name and stores it in the request context so
+// API layer and plugins can look it up via PrincipalFromContext.
+func principalHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			ctx := context.WithValue(r.Context(), principalContextKey{}, r.TLS.PeerCertificates[0].Subject.CommonName)
+			r = r.WithContext(ctx)
+		}
 
-	if err != nil {
-		return fmt.Errorf("failed to create Jaeger exporter: %w", err)
-	}
+		next.ServeHTTP(w, r)
+	})
+}
 
-	trace.RegisterExporter(je)
-	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+// trustedProxyHandler is installed when Octant sits behind a reverse
+// proxy that sets X-Forwarded-For/X-Forwarded-Host. It rewrites the
+// request so logging sees the original client address rather than the
+// proxy's, and so the websocket upgrader's origin check (which compares
+// the Origin header's host against r.Host, not r.URL.Scheme) compares
+// against the host the client actually connected to.
+func trustedProxyHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fwdFor := r.Header.Get("X-Forwarded-For"); fwdFor != "" {
+			if addr := strings.TrimSpace(strings.Split(fwdFor, ",")[0]); addr != "" {
+				port := "0"
+				if _, origPort, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+					port = origPort
+				}
+				r.RemoteAddr = net.JoinHostPort(addr, port)
+			}
+		}
+		if fwdHost := r.Header.Get("X-Forwarded-Host"); fwdHost != "" {
+			r.Host = fwdHost
+		}
 
-	return nil
+		next.ServeHTTP(w, r)
+	})
 }
@@ -0,0 +1,40 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware-tanzu/octant/pkg/action"
+)
+
+// InstallActionName is the action name the frontend (or any gRPC/HTTP
+// client) dispatches to install a plugin without shelling out to a CLI.
+const InstallActionName = "action.octant.dev/pluginInstall"
+
+// InstallAction returns an action.Manager handler that installs the
+// plugin named by the "ref" payload field, e.g.
+// {"ref": "ghcr.io/acme/octant-cert-manager:v0.3.0"}.
+func InstallAction(r *Registry) action.DispatcherFunc {
+	return func(ctx context.Context, payload action.Payload) error {
+		refString, err := payload.String("ref")
+		if err != nil {
+			return fmt.Errorf("reading ref from payload: %w", err)
+		}
+
+		ref, err := ParseRef(refString)
+		if err != nil {
+			return fmt.Errorf("parsing ref %q: %w", refString, err)
+		}
+
+		if err := r.Install(ref); err != nil {
+			return fmt.Errorf("installing %s: %w", ref, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand returns the "octant plugin" subcommand tree (pull, install,
+// list, enable, disable, remove) for managing OCI-distributed plugins
+// without shelling out. The returned command stands alone; nothing in
+// this package attaches it to Octant's root command.
+func NewCommand(reg *Registry) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage Octant plugins distributed as OCI images",
+	}
+
+	cmd.AddCommand(
+		newPullCommand(reg),
+		newInstallCommand(reg),
+		newListCommand(reg),
+		newEnableCommand(reg),
+		newDisableCommand(reg),
+		newRemoveCommand(reg),
+	)
+
+	return cmd
+}
+
+func newPullCommand(reg *Registry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pull REF",
+		Short: "Fetch a plugin image into the local cache without enabling it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := ParseRef(args[0])
+			if err != nil {
+				return err
+			}
+			return reg.Pull(ref)
+		},
+	}
+}
+
+func newInstallCommand(reg *Registry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "install REF",
+		Short: "Pull a plugin image and enable it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := ParseRef(args[0])
+			if err != nil {
+				return err
+			}
+			return reg.Install(ref)
+		},
+	}
+}
+
+func newListCommand(reg *Registry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed plugins",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			refs, err := reg.List()
+			if err != nil {
+				return err
+			}
+			for _, ref := range refs {
+				fmt.Fprintln(cmd.OutOrStdout(), ref.String())
+			}
+			return nil
+		},
+	}
+}
+
+func newEnableCommand(reg *Registry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable REF",
+		Short: "Enable an installed plugin",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := ParseRef(args[0])
+			if err != nil {
+				return err
+			}
+			return reg.Enable(ref)
+		},
+	}
+}
+
+func newDisableCommand(reg *Registry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable REF",
+		Short: "Disable an installed plugin without removing its cached binary",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := ParseRef(args[0])
+			if err != nil {
+				return err
+			}
+			return reg.Disable(ref)
+		},
+	}
+}
+
+func newRemoveCommand(reg *Registry) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove REF",
+		Short: "Remove an installed plugin's index entry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := ParseRef(args[0])
+			if err != nil {
+				return err
+			}
+			return reg.Remove(ref)
+		},
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// configFileName is the name of the plugin config layer's single file
+// within a plugin's OCI artifact.
+const configFileName = "plugin.json"
+
+// Client fetches plugin OCI artifacts from a registry. Authentication is
+// resolved the same way `docker pull` does: anonymous by default, or via
+// DOCKER_CONFIG/basic-auth credentials registered with the keychain.
+type Client struct {
+	keychain authn.Keychain
+}
+
+// NewClient creates a Client that honors DOCKER_CONFIG for registry
+// credentials, falling back to anonymous access.
+func NewClient() *Client {
+	return &Client{keychain: authn.DefaultKeychain}
+}
+
+// WithBasicAuth returns a Client that authenticates with a fixed
+// username/password instead of consulting DOCKER_CONFIG.
+func (c *Client) WithBasicAuth(username, password string) *Client {
+	return &Client{
+		keychain: authn.NewMultiKeychain(
+			authn.NewKeychainFromHelper(staticHelper{username: username, password: password}),
+			c.keychain,
+		),
+	}
+}
+
+type staticHelper struct {
+	username, password string
+}
+
+func (h staticHelper) Get(_ string) (string, string, error) {
+	return h.username, h.password, nil
+}
+
+// Fetched is a plugin image pulled from a registry: its decoded
+// plugin.json config plus a reader over the (uncompressed) plugin binary.
+type Fetched struct {
+	Config PluginConfig
+	Binary io.ReadCloser
+}
+
+// Pull fetches ref's manifest and layers, returning the plugin config and
+// an open reader over the binary layer's content. Callers are responsible
+// for closing Binary and for persisting it to a Store.
+func (c *Client) Pull(ref Ref) (*Fetched, error) {
+	imgRef, err := name.ParseReference(ref.String())
+	if err != nil {
+		return nil, fmt.Errorf("parsing ref %s: %w", ref, err)
+	}
+
+	img, err := remote.Image(imgRef, remote.WithAuthFromKeychain(c.keychain))
+	if err != nil {
+		return nil, fmt.Errorf("fetching image %s: %w", ref, err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("reading layers for %s: %w", ref, err)
+	}
+
+	var cfg *PluginConfig
+	var binary io.ReadCloser
+
+	for _, layer := range layers {
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return nil, fmt.Errorf("reading layer for %s: %w", ref, err)
+		}
+
+		pluginCfg, binaryReader, err := inspectLayer(rc)
+		if err != nil {
+			return nil, err
+		}
+
+		if pluginCfg != nil {
+			cfg = pluginCfg
+			continue
+		}
+		if binaryReader != nil {
+			binary = binaryReader
+		}
+	}
+
+	if cfg == nil {
+		return nil, fmt.Errorf("image %s has no %s config layer", ref, configFileName)
+	}
+	if binary == nil {
+		return nil, fmt.Errorf("image %s has no plugin binary layer", ref)
+	}
+
+	return &Fetched{Config: *cfg, Binary: binary}, nil
+}
+
+// inspectLayer reads a single tar layer and returns either the decoded
+// plugin.json config, or a reader over a single non-config file (the
+// binary), whichever it finds first.
+func inspectLayer(rc io.ReadCloser) (*PluginConfig, io.ReadCloser, error) {
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, nil, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading tar layer: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if hdr.Name == configFileName {
+			var cfg PluginConfig
+			if err := json.NewDecoder(tr).Decode(&cfg); err != nil {
+				return nil, nil, fmt.Errorf("decoding %s: %w", configFileName, err)
+			}
+			return &cfg, nil, nil
+		}
+
+		tmp, err := os.CreateTemp("", "octant-plugin-*")
+		if err != nil {
+			return nil, nil, fmt.Errorf("staging plugin binary: %w", err)
+		}
+		if _, err := io.Copy(tmp, tr); err != nil {
+			return nil, nil, fmt.Errorf("staging plugin binary: %w", err)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, fmt.Errorf("staging plugin binary: %w", err)
+		}
+		return nil, namedTempFile{tmp}, nil
+	}
+}
+
+// namedTempFile wraps the *os.File backing a staged plugin binary so that
+// closing it (once the caller has copied its content into the
+// content-addressable store) also removes the temp file, rather than
+// leaking a copy of every pulled binary in $TMPDIR.
+type namedTempFile struct {
+	*os.File
+}
+
+func (f namedTempFile) Close() error {
+	closeErr := f.File.Close()
+	if err := os.Remove(f.File.Name()); err != nil && !os.IsNotExist(err) && closeErr == nil {
+		return err
+	}
+	return closeErr
+}
@@ -0,0 +1,24 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+// PluginConfig is the plugin.json config layer of a plugin's OCI artifact.
+// It declares what the plugin is and what it needs, so Octant can decide
+// whether it is safe and able to run it before ever exec-ing the binary.
+type PluginConfig struct {
+	Name                 string   `json:"name"`
+	Version              string   `json:"version"`
+	OS                   string   `json:"os"`
+	Arch                 string   `json:"arch"`
+	Capabilities         []string `json:"capabilities"`
+	MinimumOctantVersion string   `json:"minimumOctantVersion"`
+}
+
+// SupportsPlatform reports whether this plugin build targets the given
+// OS/arch pair.
+func (c PluginConfig) SupportsPlatform(goos, goarch string) bool {
+	return c.OS == goos && c.Arch == goarch
+}
@@ -0,0 +1,26 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultBaseDir returns $XDG_DATA_HOME/octant/plugins, falling back to
+// ~/.local/share/octant/plugins when XDG_DATA_HOME is unset.
+func DefaultBaseDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "octant", "plugins"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "share", "octant", "plugins"), nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package registry lets Octant pull, install, and manage plugins that are
+// distributed as OCI artifacts (e.g. ghcr.io/acme/octant-cert-manager:v0.3.0)
+// instead of requiring a pre-installed binary on disk.
+package registry
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Ref identifies a plugin image, e.g. "ghcr.io/acme/octant-cert-manager:v0.3.0".
+type Ref struct {
+	Name string
+	Tag  string
+}
+
+// ParseRef splits an image reference into its name and tag, defaulting the
+// tag to "latest" when omitted.
+func ParseRef(s string) (Ref, error) {
+	if s == "" {
+		return Ref{}, fmt.Errorf("plugin ref must not be empty")
+	}
+
+	name, tag := s, "latest"
+	if i := strings.LastIndex(s, ":"); i > strings.LastIndex(s, "/") {
+		name, tag = s[:i], s[i+1:]
+	}
+
+	if name == "" {
+		return Ref{}, fmt.Errorf("invalid plugin ref %q: empty image name", s)
+	}
+
+	return Ref{Name: name, Tag: tag}, nil
+}
+
+// String returns the canonical "name:tag" form of the ref.
+func (r Ref) String() string {
+	return fmt.Sprintf("%s:%s", r.Name, r.Tag)
+}
+
+// indexKey returns the flat, filesystem-safe filename (no path separators)
+// used for this ref's entry under refs/ in the local store. A ref's name
+// routinely contains "/" (e.g. a registry host and repository path), so the
+// canonical "name:tag" form is path-escaped rather than used directly as a
+// filename; parseIndexKey reverses it.
+func (r Ref) indexKey() string {
+	return url.PathEscape(r.String())
+}
+
+// parseIndexKey reverses indexKey, recovering the Ref a refs/ filename
+// (minus its .json suffix) was written for.
+func parseIndexKey(key string) (Ref, error) {
+	s, err := url.PathUnescape(key)
+	if err != nil {
+		return Ref{}, fmt.Errorf("decoding ref filename %q: %w", key, err)
+	}
+	return ParseRef(s)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Ref
+		wantErr bool
+	}{
+		{
+			name: "name and tag",
+			in:   "ghcr.io/acme/octant-cert-manager:v0.3.0",
+			want: Ref{Name: "ghcr.io/acme/octant-cert-manager", Tag: "v0.3.0"},
+		},
+		{
+			name: "defaults to latest",
+			in:   "ghcr.io/acme/octant-cert-manager",
+			want: Ref{Name: "ghcr.io/acme/octant-cert-manager", Tag: "latest"},
+		},
+		{
+			name: "port in registry host",
+			in:   "localhost:5000/acme/octant-cert-manager:v0.3.0",
+			want: Ref{Name: "localhost:5000/acme/octant-cert-manager", Tag: "v0.3.0"},
+		},
+		{
+			name:    "empty",
+			in:      "",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRef(tc.in)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRef_String(t *testing.T) {
+	ref := Ref{Name: "ghcr.io/acme/octant-cert-manager", Tag: "v0.3.0"}
+	assert.Equal(t, "ghcr.io/acme/octant-cert-manager:v0.3.0", ref.String())
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/vmware-tanzu/octant/pkg/log"
+)
+
+// MinOctantVersionFunc reports whether a plugin's declared minimum Octant
+// version is satisfied by the running binary. It is a func so the caller
+// (which knows Octant's own build version) can inject the comparison
+// without this package importing the version package directly.
+type MinOctantVersionFunc func(minVersion string) bool
+
+// Registry resolves plugin refs to verified, on-disk binaries, pulling
+// and caching them from an OCI registry on demand.
+type Registry struct {
+	store           *Store
+	client          *Client
+	logger          log.Logger
+	supportsVersion MinOctantVersionFunc
+}
+
+// NewRegistry creates a Registry backed by a content-addressable store
+// rooted at baseDir (typically $XDG_DATA_HOME/octant/plugins).
+func NewRegistry(baseDir string, logger log.Logger, supportsVersion MinOctantVersionFunc) (*Registry, error) {
+	store, err := NewStore(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("initializing plugin store: %w", err)
+	}
+
+	return &Registry{
+		store:           store,
+		client:          NewClient(),
+		logger:          logger,
+		supportsVersion: supportsVersion,
+	}, nil
+}
+
+// Pull fetches ref from its registry and stores the binary in the local
+// content-addressable cache, without enabling it. Re-pulling a ref whose
+// binary is unchanged is a no-op past the initial hash.
+func (r *Registry) Pull(ref Ref) error {
+	fetched, err := r.client.Pull(ref)
+	if err != nil {
+		return fmt.Errorf("pulling %s: %w", ref, err)
+	}
+	defer fetched.Binary.Close()
+
+	if !fetched.Config.SupportsPlatform(runtime.GOOS, runtime.GOARCH) {
+		return fmt.Errorf("plugin %s does not support %s/%s", ref, runtime.GOOS, runtime.GOARCH)
+	}
+	if r.supportsVersion != nil && fetched.Config.MinimumOctantVersion != "" && !r.supportsVersion(fetched.Config.MinimumOctantVersion) {
+		return fmt.Errorf("plugin %s requires Octant >= %s", ref, fetched.Config.MinimumOctantVersion)
+	}
+
+	digest, err := r.store.PutBlob(fetched.Binary)
+	if err != nil {
+		return fmt.Errorf("caching %s: %w", ref, err)
+	}
+
+	_, wasEnabled, resolveErr := r.store.Resolve(ref)
+
+	if err := r.store.PutRef(ref, digest); err != nil {
+		return fmt.Errorf("indexing %s: %w", ref, err)
+	}
+
+	// PutRef marks the ref enabled; a ref that was not already enabled
+	// stays disabled until Enable is called.
+	if resolveErr != nil || !wasEnabled {
+		if err := r.store.SetEnabled(ref, false); err != nil {
+			return fmt.Errorf("indexing %s: %w", ref, err)
+		}
+	}
+
+	r.logger.With("ref", ref.String(), "digest", digest).Infof("pulled plugin")
+
+	return nil
+}
+
+// Install is Pull followed by Enable, for the common case of wanting a
+// plugin to start running immediately.
+func (r *Registry) Install(ref Ref) error {
+	if err := r.Pull(ref); err != nil {
+		return err
+	}
+	return r.Enable(ref)
+}
+
+// List returns every plugin ref known to the local store.
+func (r *Registry) List() ([]Ref, error) {
+	return r.store.List()
+}
+
+// Enable marks ref as active so the plugin manager will resolve and
+// start it.
+func (r *Registry) Enable(ref Ref) error {
+	return r.store.SetEnabled(ref, true)
+}
+
+// Disable marks ref as inactive without removing its cached binary.
+func (r *Registry) Disable(ref Ref) error {
+	return r.store.SetEnabled(ref, false)
+}
+
+// Remove deletes ref's index entry. The underlying blob stays cached in
+// case another installed ref shares it.
+func (r *Registry) Remove(ref Ref) error {
+	return r.store.RemoveRef(ref)
+}
+
+// Resolve returns the on-disk, integrity-verified path to an enabled
+// ref's binary, for the plugin manager to exec. It returns an error if
+// the ref is unknown, disabled, or fails its digest check.
+func (r *Registry) Resolve(ref Ref) (string, error) {
+	digest, enabled, err := r.store.Resolve(ref)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s: %w", ref, err)
+	}
+	if !enabled {
+		return "", fmt.Errorf("plugin %s is disabled", ref)
+	}
+
+	path, err := r.store.BlobPath(digest)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s failed verification: %w", ref, err)
+	}
+
+	return path, nil
+}
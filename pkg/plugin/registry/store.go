@@ -0,0 +1,215 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// index is the on-disk record of installed plugin refs, persisted as
+// refs/<path-escaped name:tag>.json next to the blob cache.
+type index struct {
+	Digest  string `json:"digest"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Store is a content-addressable cache of plugin binaries, keyed by their
+// sha256 digest, plus a ref index mapping name:tag to a digest. Re-pulling
+// an already-cached digest is a no-op, and BlobPath re-hashes the blob
+// before returning its path so a tampered cache entry is never silently
+// executed.
+type Store struct {
+	// baseDir is typically $XDG_DATA_HOME/octant/plugins.
+	baseDir string
+}
+
+// NewStore creates a Store rooted at baseDir, creating the blobs/ and
+// refs/ subdirectories if they do not already exist.
+func NewStore(baseDir string) (*Store, error) {
+	s := &Store{baseDir: baseDir}
+
+	for _, dir := range []string{s.blobsDir(), s.refsDir()} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating %s: %w", dir, err)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *Store) blobsDir() string {
+	return filepath.Join(s.baseDir, "blobs", "sha256")
+}
+
+func (s *Store) refsDir() string {
+	return filepath.Join(s.baseDir, "refs")
+}
+
+func (s *Store) blobPath(digest string) string {
+	return filepath.Join(s.blobsDir(), digest)
+}
+
+// PutBlob writes r to the content-addressable cache and returns its
+// sha256 digest. If a blob with the resulting digest already exists, the
+// write is skipped and the existing blob is left untouched.
+func (s *Store) PutBlob(r io.Reader) (string, error) {
+	tmp, err := ioutil.TempFile(s.blobsDir(), "blob-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temp blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), r); err != nil {
+		return "", fmt.Errorf("writing blob: %w", err)
+	}
+
+	digest := hex.EncodeToString(h.Sum(nil))
+	dest := s.blobPath(digest)
+
+	if _, err := os.Stat(dest); err == nil {
+		return digest, nil
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("closing temp blob: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return "", fmt.Errorf("making blob executable: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", fmt.Errorf("moving blob into place: %w", err)
+	}
+
+	return digest, nil
+}
+
+// VerifyBlob re-hashes the blob for digest and returns an error if the
+// bytes on disk no longer match, e.g. due to disk corruption or tampering.
+func (s *Store) VerifyBlob(digest string) (string, error) {
+	f, err := os.Open(s.blobPath(digest))
+	if err != nil {
+		return "", fmt.Errorf("opening blob %s: %w", digest, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hashing blob %s: %w", digest, err)
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if actual != digest {
+		return actual, fmt.Errorf("blob %s failed integrity check: on-disk digest is %s", digest, actual)
+	}
+
+	return actual, nil
+}
+
+// BlobPath returns the on-disk path of a verified blob.
+func (s *Store) BlobPath(digest string) (string, error) {
+	if _, err := s.VerifyBlob(digest); err != nil {
+		return "", err
+	}
+	return s.blobPath(digest), nil
+}
+
+func (s *Store) refPath(ref Ref) string {
+	return filepath.Join(s.refsDir(), fmt.Sprintf("%s.json", ref.indexKey()))
+}
+
+// PutRef records that ref resolves to digest. New refs are enabled by
+// default.
+func (s *Store) PutRef(ref Ref, digest string) error {
+	idx := index{Digest: digest, Enabled: true}
+	return s.writeIndex(ref, idx)
+}
+
+// SetEnabled toggles whether an installed ref's binary is resolved and
+// started by the plugin manager.
+func (s *Store) SetEnabled(ref Ref, enabled bool) error {
+	idx, err := s.readIndex(ref)
+	if err != nil {
+		return err
+	}
+	idx.Enabled = enabled
+	return s.writeIndex(ref, idx)
+}
+
+// RemoveRef deletes a ref's index entry. The underlying blob is left in
+// the content-addressable cache in case another ref still points at it.
+func (s *Store) RemoveRef(ref Ref) error {
+	if err := os.Remove(s.refPath(ref)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing ref %s: %w", ref, err)
+	}
+	return nil
+}
+
+// Resolve returns the digest and enabled state for an installed ref.
+func (s *Store) Resolve(ref Ref) (digest string, enabled bool, err error) {
+	idx, err := s.readIndex(ref)
+	if err != nil {
+		return "", false, err
+	}
+	return idx.Digest, idx.Enabled, nil
+}
+
+// List returns every installed ref.
+func (s *Store) List() ([]Ref, error) {
+	entries, err := ioutil.ReadDir(s.refsDir())
+	if err != nil {
+		return nil, fmt.Errorf("reading refs dir: %w", err)
+	}
+
+	var refs []Ref
+	for _, e := range entries {
+		name := e.Name()
+		const suffix = ".json"
+		if e.IsDir() || filepath.Ext(name) != suffix {
+			continue
+		}
+		ref, err := parseIndexKey(name[:len(name)-len(suffix)])
+		if err != nil {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, nil
+}
+
+func (s *Store) readIndex(ref Ref) (index, error) {
+	var idx index
+
+	b, err := ioutil.ReadFile(s.refPath(ref))
+	if err != nil {
+		return idx, fmt.Errorf("reading ref %s: %w", ref, err)
+	}
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return idx, fmt.Errorf("decoding ref %s: %w", ref, err)
+	}
+
+	return idx, nil
+}
+
+func (s *Store) writeIndex(ref Ref, idx index) error {
+	b, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding ref %s: %w", ref, err)
+	}
+	if err := ioutil.WriteFile(s.refPath(ref), b, 0o644); err != nil {
+		return fmt.Errorf("writing ref %s: %w", ref, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package registry
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_PutBlobAndVerify(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+
+	digest, err := store.PutBlob(strings.NewReader("plugin-binary-contents"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, digest)
+
+	path, err := store.BlobPath(digest)
+	require.NoError(t, err)
+	assert.FileExists(t, path)
+
+	// Re-putting identical content should dedupe to the same digest.
+	digest2, err := store.PutBlob(strings.NewReader("plugin-binary-contents"))
+	require.NoError(t, err)
+	assert.Equal(t, digest, digest2)
+}
+
+func TestStore_RefLifecycle(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+
+	ref := Ref{Name: "ghcr.io/acme/octant-cert-manager", Tag: "v0.3.0"}
+	digest, err := store.PutBlob(strings.NewReader("plugin-binary-contents"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.PutRef(ref, digest))
+
+	gotDigest, enabled, err := store.Resolve(ref)
+	require.NoError(t, err)
+	assert.Equal(t, digest, gotDigest)
+	assert.True(t, enabled)
+
+	require.NoError(t, store.SetEnabled(ref, false))
+	_, enabled, err = store.Resolve(ref)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	refs, err := store.List()
+	require.NoError(t, err)
+	assert.Contains(t, refs, ref)
+
+	require.NoError(t, store.RemoveRef(ref))
+	refs, err = store.List()
+	require.NoError(t, err)
+	assert.NotContains(t, refs, ref)
+}